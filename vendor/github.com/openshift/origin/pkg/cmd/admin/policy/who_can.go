@@ -1,17 +1,22 @@
 package policy
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"text/tabwriter"
 
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/meta"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/util/sets"
 
 	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
 	"github.com/openshift/origin/pkg/client"
@@ -24,14 +29,28 @@ type whoCanOptions struct {
 	allNamespaces    bool
 	bindingNamespace string
 	client           *client.Client
+	out              io.Writer
 
-	verb     string
-	resource unversioned.GroupVersionResource
+	list         bool
+	allGroups    bool
+	output       string
+	showBindings bool
+
+	verb           string
+	resource       unversioned.GroupVersionResource
+	subresource    string
+	nonResourceURL string
+
+	// groupMatches holds every GroupVersionResource that matched the
+	// requested resource when it is ambiguous (served by more than one
+	// API group) and --all-groups was given to resolve the ambiguity by
+	// querying all of them.
+	groupMatches []unversioned.GroupVersionResource
 }
 
 // NewCmdWhoCan implements the OpenShift cli who-can command
 func NewCmdWhoCan(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
-	options := &whoCanOptions{}
+	options := &whoCanOptions{out: out}
 
 	cmd := &cobra.Command{
 		Use:   "who-can VERB RESOURCE",
@@ -49,88 +68,695 @@ func NewCmdWhoCan(name, fullName string, f *clientcmd.Factory, out io.Writer) *c
 			options.bindingNamespace, _, err = f.DefaultNamespace()
 			kcmdutil.CheckErr(err)
 
-			err = options.run()
+			if options.list {
+				err = options.runList()
+			} else {
+				err = options.run()
+			}
 			kcmdutil.CheckErr(err)
 		},
 	}
 
 	cmd.Flags().BoolVar(&options.allNamespaces, "all-namespaces", options.allNamespaces, "If present, list who can perform the specified action in all namespaces.")
+	cmd.Flags().BoolVar(&options.list, "list", options.list, "If present, list every action the current user (or impersonated --as/--as-group subject) can perform, instead of asking about a single verb and resource.")
+	cmd.Flags().BoolVar(&options.allGroups, "all-groups", options.allGroups, "If present, when RESOURCE is ambiguous because it is served by more than one API group, query all of them instead of erroring out.")
+	cmd.Flags().StringVarP(&options.output, "output", "o", options.output, "Output format. One of: json|yaml|wide|name.")
+	cmd.Flags().BoolVar(&options.showBindings, "show-bindings", options.showBindings, "If present, also print which (Cluster)Role and (Cluster)RoleBinding granted the permission to each subject.")
 
 	return cmd
 }
 
 func (o *whoCanOptions) complete(f *clientcmd.Factory, args []string) error {
+	if o.list {
+		if len(args) != 0 {
+			return errors.New("--list takes no arguments")
+		}
+		if len(o.output) > 0 {
+			return errors.New("--list does not support -o/--output")
+		}
+		if o.showBindings {
+			return errors.New("--list does not support --show-bindings")
+		}
+		return nil
+	}
+
 	if len(args) != 2 {
 		return errors.New("you must specify two arguments: verb and resource")
 	}
 
+	o.verb = args[0]
+
+	if strings.HasPrefix(args[1], "/") {
+		o.nonResourceURL = args[1]
+		return nil
+	}
+
+	resourceArg := args[1]
+	if idx := strings.IndexByte(resourceArg, '/'); idx != -1 {
+		o.subresource = resourceArg[idx+1:]
+		resourceArg = resourceArg[:idx]
+	}
+
 	restMapper, _ := f.Object()
+	matches, err := resourcesFor(restMapper, resourceArg)
+	if err != nil {
+		return err
+	}
 
-	o.verb = args[0]
-	o.resource = resourceFor(restMapper, args[1])
+	if len(matches) <= 1 {
+		if len(matches) == 1 {
+			o.resource = matches[0]
+		} else {
+			o.resource = unversioned.GroupVersionResource{Resource: resourceArg}
+		}
+		return nil
+	}
 
+	if !o.allGroups {
+		groups := make([]string, 0, len(matches))
+		for _, match := range matches {
+			groups = append(groups, match.Group)
+		}
+		sort.Strings(groups)
+		return fmt.Errorf("resource %q is ambiguous, it is provided by multiple API groups (%s); specify the group explicitly (e.g. %s.%s) or pass --all-groups to query every group that serves it", resourceArg, strings.Join(groups, ", "), resourceArg, groups[0])
+	}
+
+	o.groupMatches = matches
 	return nil
 }
 
-func resourceFor(mapper meta.RESTMapper, resourceArg string) unversioned.GroupVersionResource {
+// resourcesFor resolves a (possibly short or unqualified) resource argument
+// to every GroupVersionResource the discovery-backed REST mapper knows
+// about. A bare resource name like "pods" served by a single API group
+// resolves to exactly one match; a name like "deployments" that is served
+// by both "extensions" and "apps" resolves to one match per group, which
+// the caller must then disambiguate.
+func resourcesFor(mapper meta.RESTMapper, resourceArg string) ([]unversioned.GroupVersionResource, error) {
 	fullySpecifiedGVR, groupResource := unversioned.ParseResourceArg(strings.ToLower(resourceArg))
-	gvr := unversioned.GroupVersionResource{}
 	if fullySpecifiedGVR != nil {
-		gvr, _ = mapper.ResourceFor(*fullySpecifiedGVR)
+		if gvr, err := mapper.ResourceFor(*fullySpecifiedGVR); err == nil && !gvr.IsEmpty() {
+			return []unversioned.GroupVersionResource{gvr}, nil
+		}
 	}
-	if gvr.IsEmpty() {
-		var err error
-		gvr, err = mapper.ResourceFor(groupResource.WithVersion(""))
-		if err != nil {
-			return unversioned.GroupVersionResource{Resource: resourceArg}
+
+	matches, err := mapper.ResourcesFor(groupResource.WithVersion(""))
+	if err != nil {
+		// A genuine mapper/discovery failure (e.g. the API server is
+		// unreachable) must be reported, not confused with "this resource
+		// name isn't known, fall back to using it literally" — only the
+		// latter is safe to swallow here.
+		if meta.IsNoMatchError(err) {
+			return nil, nil
 		}
+		return nil, err
 	}
 
-	return gvr
+	return matches, nil
+}
+
+// whoCanResult is the structured form of a single who-can query, printed
+// through the -o json|yaml|wide|name printer.
+//
+// This intentionally has no Incomplete/EvaluationErrors fields: unlike
+// SelfSubjectRulesReviewStatus (see status.Incomplete in runList below),
+// ResourceAccessReviewResponse carries no equivalent signal, so there is
+// nothing real to put in them for a single verb/resource query. --list
+// remains the only mode that can report partial results.
+type whoCanResult struct {
+	Namespace       string                  `json:"namespace"`
+	Verb            string                  `json:"verb"`
+	Group           string                  `json:"group,omitempty"`
+	Resource        string                  `json:"resource,omitempty"`
+	Subresource     string                  `json:"subresource,omitempty"`
+	NonResourceURL  string                  `json:"nonResourceURL,omitempty"`
+	Users           []string                `json:"users"`
+	Groups          []string                `json:"groups"`
+	ServiceAccounts []serviceAccountSubject `json:"serviceAccounts,omitempty"`
+	Bindings        []string                `json:"bindings,omitempty"`
+}
+
+// serviceAccountSubject is a "system:serviceaccount:<namespace>:<name>"
+// username split into its constituent parts.
+type serviceAccountSubject struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
 }
 
 func (o *whoCanOptions) run() error {
+	resources := o.groupMatches
+	if len(resources) == 0 {
+		resources = []unversioned.GroupVersionResource{o.resource}
+	}
+
+	results := make([]whoCanResult, 0, len(resources))
+	for _, resource := range resources {
+		response, err := o.reviewFor(resource)
+		if err != nil {
+			return err
+		}
+
+		result, err := o.buildResult(resource, response)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+	}
+
+	return o.printResults(results)
+}
+
+// buildResult turns a ResourceAccessReviewResponse into the structured
+// whoCanResult used by every output format. In wide mode it additionally
+// looks up which (Cluster)RoleBindings granted the permission.
+func (o *whoCanOptions) buildResult(resource unversioned.GroupVersionResource, response *authorizationapi.ResourceAccessReviewResponse) (whoCanResult, error) {
+	users, serviceAccounts := splitServiceAccounts(response.Users)
+
+	result := whoCanResult{
+		Namespace:       response.Namespace,
+		Verb:            o.verb,
+		Group:           resource.Group,
+		Resource:        resource.Resource,
+		Subresource:     o.subresource,
+		NonResourceURL:  o.nonResourceURL,
+		Users:           users,
+		Groups:          response.Groups.List(),
+		ServiceAccounts: serviceAccounts,
+	}
+
+	if o.output == "wide" || o.showBindings {
+		bindings, err := o.grantingBindings(resource)
+		if err != nil {
+			return whoCanResult{}, err
+		}
+		result.Bindings = bindings
+	}
+
+	return result, nil
+}
+
+// splitServiceAccounts pulls "system:serviceaccount:<namespace>:<name>"
+// usernames out of users so they can be rendered in their own column.
+func splitServiceAccounts(users sets.String) (plainUsers []string, serviceAccounts []serviceAccountSubject) {
+	const prefix = "system:serviceaccount:"
+
+	for _, user := range users.List() {
+		if !strings.HasPrefix(user, prefix) {
+			plainUsers = append(plainUsers, user)
+			continue
+		}
+
+		parts := strings.SplitN(strings.TrimPrefix(user, prefix), ":", 2)
+		if len(parts) != 2 {
+			plainUsers = append(plainUsers, user)
+			continue
+		}
+
+		serviceAccounts = append(serviceAccounts, serviceAccountSubject{Namespace: parts[0], Name: parts[1]})
+	}
+
+	return plainUsers, serviceAccounts
+}
+
+func (o *whoCanOptions) reviewFor(resource unversioned.GroupVersionResource) (*authorizationapi.ResourceAccessReviewResponse, error) {
 	authorizationAttributes := authorizationapi.AuthorizationAttributes{
-		Verb:     o.verb,
-		Group:    o.resource.Group,
-		Resource: o.resource.Resource,
+		Verb:           o.verb,
+		Group:          resource.Group,
+		Resource:       resource.Resource,
+		Subresource:    o.subresource,
+		NonResourceURL: o.nonResourceURL,
 	}
 
-	resourceAccessReviewResponse := &authorizationapi.ResourceAccessReviewResponse{}
-	var err error
 	if o.allNamespaces {
-		resourceAccessReviewResponse, err = o.client.ResourceAccessReviews().Create(&authorizationapi.ResourceAccessReview{Action: authorizationAttributes})
-	} else {
-		resourceAccessReviewResponse, err = o.client.LocalResourceAccessReviews(o.bindingNamespace).Create(&authorizationapi.LocalResourceAccessReview{Action: authorizationAttributes})
+		return o.client.ResourceAccessReviews().Create(&authorizationapi.ResourceAccessReview{Action: authorizationAttributes})
+	}
+	return o.client.LocalResourceAccessReviews(o.bindingNamespace).Create(&authorizationapi.LocalResourceAccessReview{Action: authorizationAttributes})
+}
+
+func resourceDisplayFor(resource unversioned.GroupVersionResource, subresource string) string {
+	resourceDisplay := resource.Resource
+	if len(resource.Group) > 0 {
+		resourceDisplay = resourceDisplay + "." + resource.Group
+	}
+	if len(subresource) > 0 {
+		resourceDisplay = resourceDisplay + "/" + subresource
 	}
+	return resourceDisplay
+}
+
+func namespaceDisplay(namespace string) string {
+	if namespace == kapi.NamespaceAll {
+		return "<all>"
+	}
+	return namespace
+}
+
+// printResults renders the query results in the format requested with
+// -o/--output: json, yaml, wide, name, or (the default) the original
+// human-readable text.
+func (o *whoCanOptions) printResults(results []whoCanResult) error {
+	switch o.output {
+	case "json":
+		return o.printObject(results, func(v interface{}) ([]byte, error) { return json.MarshalIndent(v, "", "  ") })
+	case "yaml":
+		return o.printObject(results, yaml.Marshal)
+	case "wide":
+		return o.printWide(results)
+	case "name":
+		return o.printNames(results)
+	case "":
+		return o.printText(results)
+	default:
+		return fmt.Errorf("unrecognized output format %q, must be one of: json, yaml, wide, name", o.output)
+	}
+}
 
+// printObject marshals the results as an array, regardless of how many
+// there are, so a script consuming -o json|yaml sees one consistent
+// top-level shape whether the query matched a single group or was
+// disambiguated across several with --all-groups.
+func (o *whoCanOptions) printObject(results []whoCanResult, marshal func(interface{}) ([]byte, error)) error {
+	data, err := marshal(results)
 	if err != nil {
 		return err
 	}
 
-	if resourceAccessReviewResponse.Namespace == kapi.NamespaceAll {
-		fmt.Printf("Namespace: <all>\n")
-	} else {
-		fmt.Printf("Namespace: %s\n", resourceAccessReviewResponse.Namespace)
+	fmt.Fprintln(o.out, string(data))
+	return nil
+}
+
+func (o *whoCanOptions) printText(results []whoCanResult) error {
+	for _, result := range results {
+		if len(result.Group) > 0 && len(o.groupMatches) > 0 {
+			fmt.Fprintf(o.out, "API group: %s\n", result.Group)
+		}
+
+		fmt.Fprintf(o.out, "Namespace: %s\n", namespaceDisplay(result.Namespace))
+		fmt.Fprintf(o.out, "Verb:      %s\n", result.Verb)
+		if len(result.NonResourceURL) > 0 {
+			fmt.Fprintf(o.out, "Non-Resource URL: %s\n\n", result.NonResourceURL)
+		} else {
+			resource := unversioned.GroupVersionResource{Group: result.Group, Resource: result.Resource}
+			fmt.Fprintf(o.out, "Resource:  %s\n\n", resourceDisplayFor(resource, result.Subresource))
+		}
+
+		if len(result.Users) == 0 {
+			fmt.Fprintf(o.out, "Users:  none\n\n")
+		} else {
+			fmt.Fprintf(o.out, "Users:  %s\n\n", strings.Join(result.Users, "\n        "))
+		}
+
+		if len(result.Groups) == 0 {
+			fmt.Fprintf(o.out, "Groups: none\n\n")
+		} else {
+			fmt.Fprintf(o.out, "Groups: %s\n\n", strings.Join(result.Groups, "\n        "))
+		}
+
+		if o.showBindings {
+			if len(result.Bindings) == 0 {
+				fmt.Fprintf(o.out, "Granted by: none\n\n")
+			} else {
+				fmt.Fprintf(o.out, "Granted by:\n  %s\n\n", strings.Join(result.Bindings, "\n  "))
+			}
+		}
 	}
 
-	resourceDisplay := o.resource.Resource
-	if len(o.resource.Group) > 0 {
-		resourceDisplay = resourceDisplay + "." + o.resource.Group
+	return nil
+}
+
+// printWide renders a scriptable-but-dense table: one row per result, with
+// service account subjects and granting bindings broken into their own
+// columns.
+func (o *whoCanOptions) printWide(results []whoCanResult) error {
+	w := tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tVERB\tRESOURCE\tUSERS\tGROUPS\tSERVICE ACCOUNTS\tGRANTED BY")
+	for _, result := range results {
+		resource := unversioned.GroupVersionResource{Group: result.Group, Resource: result.Resource}
+		resourceDisplay := result.NonResourceURL
+		if len(resourceDisplay) == 0 {
+			resourceDisplay = resourceDisplayFor(resource, result.Subresource)
+		}
+
+		serviceAccounts := make([]string, 0, len(result.ServiceAccounts))
+		for _, sa := range result.ServiceAccounts {
+			serviceAccounts = append(serviceAccounts, fmt.Sprintf("%s/%s", sa.Namespace, sa.Name))
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			namespaceDisplay(result.Namespace),
+			result.Verb,
+			resourceDisplay,
+			joinOrNone(result.Users),
+			joinOrNone(result.Groups),
+			joinOrNone(serviceAccounts),
+			joinOrNone(result.Bindings),
+		)
+	}
+
+	return w.Flush()
+}
+
+// printNames renders just the subjects allowed to perform the action, one
+// per line, analogous to kubectl's -o name.
+func (o *whoCanOptions) printNames(results []whoCanResult) error {
+	for _, result := range results {
+		for _, user := range result.Users {
+			fmt.Fprintf(o.out, "user/%s\n", user)
+		}
+		for _, group := range result.Groups {
+			fmt.Fprintf(o.out, "group/%s\n", group)
+		}
+		for _, sa := range result.ServiceAccounts {
+			fmt.Fprintf(o.out, "serviceaccount/%s/%s\n", sa.Namespace, sa.Name)
+		}
 	}
 
-	fmt.Printf("Verb:      %s\n", o.verb)
-	fmt.Printf("Resource:  %s\n\n", resourceDisplay)
-	if len(resourceAccessReviewResponse.Users) == 0 {
-		fmt.Printf("Users:  none\n\n")
-	} else {
-		fmt.Printf("Users:  %s\n\n", strings.Join(resourceAccessReviewResponse.Users.List(), "\n        "))
+	return nil
+}
+
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "<none>"
+	}
+	return strings.Join(values, ",")
+}
+
+// grantingBindings looks up which (Cluster)RoleBindings grant the requested
+// verb against resource to any subject, for display in wide mode and with
+// --show-bindings. It walks the PolicyRules of every cluster role and of
+// every role in the target namespace (in every namespace when
+// --all-namespaces was given) looking for a match, then intersects the
+// matching roles with the bindings that tie subjects to them.
+func (o *whoCanOptions) grantingBindings(resource unversioned.GroupVersionResource) ([]string, error) {
+	var grants []string
+
+	clusterPolicies, err := o.client.ClusterPolicies().List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	clusterBindings, err := o.client.ClusterPolicyBindings().List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range clusterPolicies.Items {
+		for roleName, role := range policy.Roles {
+			if !rulesMatch(role.Rules, o.verb, resource.Group, resource.Resource, o.subresource, o.nonResourceURL) {
+				continue
+			}
+			for _, binding := range clusterBindings.Items {
+				for bindingName, roleBinding := range binding.RoleBindings {
+					if roleBinding.RoleRef.Name != roleName {
+						continue
+					}
+					for _, subject := range roleBinding.Subjects {
+						grants = append(grants, fmt.Sprintf("clusterrolebinding/%s -> clusterrole/%s (subject: %s)", bindingName, roleName, describeSubject(subject)))
+					}
+				}
+			}
+		}
+	}
+
+	namespaces := []string{o.bindingNamespace}
+	if o.allNamespaces {
+		// The access review itself was cluster-wide (ResourceAccessReviews),
+		// which aggregates grants from namespaced RoleBindings across every
+		// namespace, so the binding lookup has to walk every namespace too,
+		// or the "Granted by:" section would silently miss every
+		// namespace-scoped grant.
+		namespaceList, err := o.client.Namespaces().List(kapi.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		namespaces = make([]string, 0, len(namespaceList.Items))
+		for _, ns := range namespaceList.Items {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+
+	for _, namespace := range namespaces {
+		policies, err := o.client.Policies(namespace).List(kapi.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		bindings, err := o.client.PolicyBindings(namespace).List(kapi.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, policy := range policies.Items {
+			for roleName, role := range policy.Roles {
+				if !rulesMatch(role.Rules, o.verb, resource.Group, resource.Resource, o.subresource, o.nonResourceURL) {
+					continue
+				}
+				for _, binding := range bindings.Items {
+					for bindingName, roleBinding := range binding.RoleBindings {
+						if roleBinding.RoleRef.Name != roleName {
+							continue
+						}
+						for _, subject := range roleBinding.Subjects {
+							grants = append(grants, fmt.Sprintf("rolebinding/%s.%s -> role/%s (subject: %s)", bindingName, namespace, roleName, describeSubject(subject)))
+						}
+					}
+				}
+			}
+		}
+	}
+
+	sort.Strings(grants)
+	return grants, nil
+}
+
+// rulesMatch reports whether any of rules permits verb against the given
+// group/resource/subresource (or non-resource URL). It plays the same role
+// here that rbac.VisitRulesFor plays for upstream RBAC: deciding whether a
+// role grants a specific action.
+func rulesMatch(rules []authorizationapi.PolicyRule, verb, group, resource, subresource, nonResourceURL string) bool {
+	for _, rule := range rules {
+		if ruleMatches(rule, verb, group, resource, subresource, nonResourceURL) {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleMatches(rule authorizationapi.PolicyRule, verb, group, resource, subresource, nonResourceURL string) bool {
+	if !rule.Verbs.Has("*") && !rule.Verbs.Has(verb) {
+		return false
+	}
+
+	if len(nonResourceURL) > 0 {
+		for _, url := range rule.NonResourceURLs {
+			if nonResourceURLMatches(url, nonResourceURL) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(rule.APIGroups) > 0 {
+		matchesGroup := false
+		for _, g := range rule.APIGroups {
+			if g == "*" || g == group {
+				matchesGroup = true
+				break
+			}
+		}
+		if !matchesGroup {
+			return false
+		}
+	}
+
+	target := resource
+	if len(subresource) > 0 {
+		target = resource + "/" + subresource
+	}
+
+	return rule.Resources.Has("*") || rule.Resources.Has(target) || rule.Resources.Has(resource)
+}
+
+// nonResourceURLMatches reports whether a rule's NonResourceURLs entry
+// covers the queried URL. Entries are either an exact match, the
+// bare wildcard "*", or a prefix wildcard like "/apis/*", which covers any
+// URL sharing that prefix (the same convention RBAC non-resource rules use
+// for things like "/apis/*" or "/healthz/*").
+func nonResourceURLMatches(rule, nonResourceURL string) bool {
+	if rule == "*" || rule == nonResourceURL {
+		return true
+	}
+	if prefix := strings.TrimSuffix(rule, "*"); strings.HasSuffix(rule, "*") && len(prefix) > 0 {
+		return strings.HasPrefix(nonResourceURL, prefix)
+	}
+	return false
+}
+
+// describeSubject renders a role binding subject the same way "Granted by:"
+// lines do: "user <name>", "group <name>" or "serviceaccount <ns>/<name>".
+func describeSubject(subject kapi.ObjectReference) string {
+	switch subject.Kind {
+	case authorizationapi.ServiceAccountKind:
+		return fmt.Sprintf("serviceaccount %s/%s", subject.Namespace, subject.Name)
+	case authorizationapi.GroupKind, authorizationapi.SystemGroupKind:
+		return fmt.Sprintf("group %s", subject.Name)
+	default:
+		return fmt.Sprintf("user %s", subject.Name)
+	}
+}
+
+// ruleRow is a single (resource, verb, resourceName, nonResourceURL) tuple
+// expanded out of a PolicyRule, ready to be rendered as one table row.
+type ruleRow struct {
+	resource       string
+	nonResourceURL string
+	resourceName   string
+	verb           string
+}
+
+// ruleRowsByName sorts ruleRows by resource, then non-resource URL, then
+// resource name, then verb.
+type ruleRowsByName []ruleRow
+
+func (r ruleRowsByName) Len() int      { return len(r) }
+func (r ruleRowsByName) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r ruleRowsByName) Less(i, j int) bool {
+	if r[i].resource != r[j].resource {
+		return r[i].resource < r[j].resource
+	}
+	if r[i].nonResourceURL != r[j].nonResourceURL {
+		return r[i].nonResourceURL < r[j].nonResourceURL
+	}
+	if r[i].resourceName != r[j].resourceName {
+		return r[i].resourceName < r[j].resourceName
+	}
+	return r[i].verb < r[j].verb
+}
+
+// runList implements the --list mode: it fetches the compact set of rules
+// the current (or impersonated) subject is bound to and expands every rule
+// into the cross product of its APIGroups x Resources x Verbs (and,
+// separately, its ResourceNames and NonResourceURLs) so it can be rendered
+// as a flat table of everything the subject is allowed to do.
+func (o *whoCanOptions) runList() error {
+	rulesReview := &authorizationapi.SelfSubjectRulesReview{}
+
+	var status authorizationapi.SelfSubjectRulesReviewStatus
+	if o.allNamespaces {
+		namespaces, err := o.client.Namespaces().List(kapi.ListOptions{})
+		if err != nil {
+			return err
+		}
+		seen := map[ruleRow]bool{}
+		var rows []ruleRow
+		for _, ns := range namespaces.Items {
+			response, err := o.client.SelfSubjectRulesReviews(ns.Name).Create(rulesReview)
+			if err != nil {
+				return err
+			}
+			for _, row := range expandRules(response.Status.Rules) {
+				if !seen[row] {
+					seen[row] = true
+					rows = append(rows, row)
+				}
+			}
+			if response.Status.Incomplete {
+				status.Incomplete = true
+			}
+		}
+		return printRuleRows(o.out, rows, status.Incomplete)
+	}
+
+	response, err := o.client.SelfSubjectRulesReviews(o.bindingNamespace).Create(rulesReview)
+	if err != nil {
+		return err
+	}
+	status = response.Status
+
+	return printRuleRows(o.out, expandRules(status.Rules), status.Incomplete)
+}
+
+// expandRules cross-joins every PolicyRule's APIGroups x Resources x Verbs
+// (and separately its ResourceNames and NonResourceURLs) into individual
+// rows, then deduplicates and sorts them.
+func expandRules(rules []authorizationapi.PolicyRule) []ruleRow {
+	seen := map[ruleRow]bool{}
+	var rows []ruleRow
+
+	add := func(row ruleRow) {
+		if !seen[row] {
+			seen[row] = true
+			rows = append(rows, row)
+		}
+	}
+
+	for _, rule := range rules {
+		// A rule that only grants NonResourceURLs (the normal shape for a
+		// URL-only rule) has no Resources/APIGroups to cross-join; skip the
+		// resource expansion entirely so it doesn't emit a phantom
+		// blank-resource row alongside its real NonResourceURLs rows.
+		if len(rule.Resources) > 0 || len(rule.APIGroups) > 0 {
+			resources := rule.Resources.List()
+			if len(resources) == 0 {
+				resources = []string{""}
+			}
+			groups := rule.APIGroups
+			if len(groups) == 0 {
+				groups = []string{""}
+			}
+
+			for _, group := range groups {
+				for _, resource := range resources {
+					resourceDisplay := resource
+					if len(group) > 0 && len(resource) > 0 {
+						resourceDisplay = resource + "." + group
+					}
+
+					for _, verb := range rule.Verbs.List() {
+						if len(rule.ResourceNames) == 0 {
+							add(ruleRow{resource: resourceDisplay, verb: verb})
+							continue
+						}
+						for _, resourceName := range rule.ResourceNames.List() {
+							add(ruleRow{resource: resourceDisplay, resourceName: resourceName, verb: verb})
+						}
+					}
+				}
+			}
+		}
+
+		for _, nonResourceURL := range rule.NonResourceURLs {
+			for _, verb := range rule.Verbs.List() {
+				add(ruleRow{nonResourceURL: nonResourceURL, verb: verb})
+			}
+		}
+	}
+
+	sort.Sort(ruleRowsByName(rows))
+
+	return rows
+}
+
+// printRuleRows renders the expanded rule rows as a tabwriter table.
+func printRuleRows(out io.Writer, rows []ruleRow, incomplete bool) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "Resources\tNon-Resource URLs\tResource Names\tVerbs")
+	for _, row := range rows {
+		resource := row.resource
+		if len(resource) == 0 {
+			resource = "-"
+		}
+		nonResourceURL := row.nonResourceURL
+		if len(nonResourceURL) == 0 {
+			nonResourceURL = "-"
+		}
+		resourceName := row.resourceName
+		if len(resourceName) == 0 {
+			resourceName = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", resource, nonResourceURL, resourceName, row.verb)
+	}
+	if err := w.Flush(); err != nil {
+		return err
 	}
 
-	if len(resourceAccessReviewResponse.Groups) == 0 {
-		fmt.Printf("Groups: none\n\n")
-	} else {
-		fmt.Printf("Groups: %s\n\n", strings.Join(resourceAccessReviewResponse.Groups.List(), "\n        "))
+	if incomplete {
+		fmt.Fprintln(out, "\nWarning: the list of rules is incomplete, some authorizers could not enumerate their rules")
 	}
 
 	return nil
@@ -0,0 +1,223 @@
+package policy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+)
+
+func TestExpandRulesURLOnlyRuleHasNoPhantomResourceRow(t *testing.T) {
+	rules := []authorizationapi.PolicyRule{
+		{
+			Verbs:           sets.NewString("get"),
+			NonResourceURLs: []string{"/healthz"},
+		},
+	}
+
+	rows := expandRules(rules)
+
+	want := []ruleRow{{nonResourceURL: "/healthz", verb: "get"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("expandRules(%#v) = %#v, want %#v", rules, rows, want)
+	}
+}
+
+func TestExpandRulesMixedRule(t *testing.T) {
+	rules := []authorizationapi.PolicyRule{
+		{
+			Verbs:     sets.NewString("get", "list"),
+			Resources: sets.NewString("pods"),
+		},
+		{
+			Verbs:           sets.NewString("get"),
+			NonResourceURLs: []string{"/healthz", "/metrics"},
+		},
+	}
+
+	rows := expandRules(rules)
+
+	want := []ruleRow{
+		{nonResourceURL: "/healthz", verb: "get"},
+		{nonResourceURL: "/metrics", verb: "get"},
+		{resource: "pods", verb: "get"},
+		{resource: "pods", verb: "list"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("expandRules(%#v) = %#v, want %#v", rules, rows, want)
+	}
+}
+
+func TestExpandRulesCrossJoinsGroupsResourcesAndResourceNames(t *testing.T) {
+	rules := []authorizationapi.PolicyRule{
+		{
+			Verbs:         sets.NewString("get"),
+			APIGroups:     []string{"apps", "extensions"},
+			Resources:     sets.NewString("deployments"),
+			ResourceNames: sets.NewString("web"),
+		},
+	}
+
+	rows := expandRules(rules)
+
+	want := []ruleRow{
+		{resource: "deployments.apps", resourceName: "web", verb: "get"},
+		{resource: "deployments.extensions", resourceName: "web", verb: "get"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("expandRules(%#v) = %#v, want %#v", rules, rows, want)
+	}
+}
+
+func TestNonResourceURLMatches(t *testing.T) {
+	tests := []struct {
+		rule           string
+		nonResourceURL string
+		want           bool
+	}{
+		{rule: "/healthz", nonResourceURL: "/healthz", want: true},
+		{rule: "/healthz", nonResourceURL: "/healthz/ping", want: false},
+		{rule: "*", nonResourceURL: "/anything", want: true},
+		{rule: "/apis/*", nonResourceURL: "/apis/apps/v1", want: true},
+		{rule: "/apis/*", nonResourceURL: "/api/v1", want: false},
+	}
+
+	for _, test := range tests {
+		if got := nonResourceURLMatches(test.rule, test.nonResourceURL); got != test.want {
+			t.Errorf("nonResourceURLMatches(%q, %q) = %v, want %v", test.rule, test.nonResourceURL, got, test.want)
+		}
+	}
+}
+
+func TestRuleMatchesNonResourceURLPrefix(t *testing.T) {
+	rule := authorizationapi.PolicyRule{
+		Verbs:           sets.NewString("get"),
+		NonResourceURLs: []string{"/apis/*"},
+	}
+
+	if !ruleMatches(rule, "get", "", "", "", "/apis/apps/v1") {
+		t.Errorf("expected rule with NonResourceURLs %v to match /apis/apps/v1", rule.NonResourceURLs)
+	}
+	if ruleMatches(rule, "get", "", "", "", "/api/v1") {
+		t.Errorf("did not expect rule with NonResourceURLs %v to match /api/v1", rule.NonResourceURLs)
+	}
+}
+
+func TestRulesMatchResource(t *testing.T) {
+	rules := []authorizationapi.PolicyRule{
+		{
+			Verbs:     sets.NewString("get", "list"),
+			APIGroups: []string{"apps"},
+			Resources: sets.NewString("deployments"),
+		},
+	}
+
+	if !rulesMatch(rules, "get", "apps", "deployments", "", "") {
+		t.Errorf("expected rules to match get deployments.apps")
+	}
+	if rulesMatch(rules, "delete", "apps", "deployments", "", "") {
+		t.Errorf("did not expect rules to match delete deployments.apps")
+	}
+	if rulesMatch(rules, "get", "extensions", "deployments", "", "") {
+		t.Errorf("did not expect rules to match a different API group")
+	}
+}
+
+func TestSplitServiceAccounts(t *testing.T) {
+	users := sets.NewString(
+		"alice",
+		"system:serviceaccount:myproject:builder",
+		"system:serviceaccount:otherproject:deployer",
+		"bob",
+	)
+
+	plainUsers, serviceAccounts := splitServiceAccounts(users)
+
+	wantUsers := []string{"alice", "bob"}
+	if !reflect.DeepEqual(plainUsers, wantUsers) {
+		t.Errorf("plainUsers = %#v, want %#v", plainUsers, wantUsers)
+	}
+
+	wantServiceAccounts := []serviceAccountSubject{
+		{Namespace: "myproject", Name: "builder"},
+		{Namespace: "otherproject", Name: "deployer"},
+	}
+	if !reflect.DeepEqual(serviceAccounts, wantServiceAccounts) {
+		t.Errorf("serviceAccounts = %#v, want %#v", serviceAccounts, wantServiceAccounts)
+	}
+}
+
+// fakeRESTMapper satisfies meta.RESTMapper by embedding a nil instance and
+// overriding only the methods resourcesFor actually calls.
+type fakeRESTMapper struct {
+	meta.RESTMapper
+
+	resourceFor  func(unversioned.GroupVersionResource) (unversioned.GroupVersionResource, error)
+	resourcesFor func(unversioned.GroupVersionResource) ([]unversioned.GroupVersionResource, error)
+}
+
+func (m fakeRESTMapper) ResourceFor(resource unversioned.GroupVersionResource) (unversioned.GroupVersionResource, error) {
+	if m.resourceFor == nil {
+		return unversioned.GroupVersionResource{}, errors.New("not implemented")
+	}
+	return m.resourceFor(resource)
+}
+
+func (m fakeRESTMapper) ResourcesFor(resource unversioned.GroupVersionResource) ([]unversioned.GroupVersionResource, error) {
+	return m.resourcesFor(resource)
+}
+
+func TestResourcesForAmbiguousResource(t *testing.T) {
+	want := []unversioned.GroupVersionResource{
+		{Group: "extensions", Resource: "deployments"},
+		{Group: "apps", Resource: "deployments"},
+	}
+	mapper := fakeRESTMapper{
+		resourcesFor: func(unversioned.GroupVersionResource) ([]unversioned.GroupVersionResource, error) {
+			return want, nil
+		},
+	}
+
+	got, err := resourcesFor(mapper, "deployments")
+	if err != nil {
+		t.Fatalf("resourcesFor returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resourcesFor(%q) = %#v, want %#v", "deployments", got, want)
+	}
+}
+
+func TestResourcesForUnknownResourceFallsBackWithoutError(t *testing.T) {
+	mapper := fakeRESTMapper{
+		resourcesFor: func(unversioned.GroupVersionResource) ([]unversioned.GroupVersionResource, error) {
+			return nil, &meta.NoResourceMatchError{PartialResource: unversioned.GroupVersionResource{Resource: "widgets"}}
+		},
+	}
+
+	got, err := resourcesFor(mapper, "widgets")
+	if err != nil {
+		t.Fatalf("resourcesFor returned error for an unrecognized resource: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("resourcesFor(%q) = %#v, want no matches", "widgets", got)
+	}
+}
+
+func TestResourcesForPropagatesGenuineMapperErrors(t *testing.T) {
+	boom := errors.New("discovery unavailable")
+	mapper := fakeRESTMapper{
+		resourcesFor: func(unversioned.GroupVersionResource) ([]unversioned.GroupVersionResource, error) {
+			return nil, boom
+		},
+	}
+
+	_, err := resourcesFor(mapper, "pods")
+	if err != boom {
+		t.Errorf("resourcesFor(%q) error = %v, want %v", "pods", err, boom)
+	}
+}